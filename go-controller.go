@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	pausedTypeKeyFmt = "paused_types:%s"
+	pauseRequeueDelay = 2 * time.Second
+)
+
+// Controller centralizes job lifecycle operations (launch, stop, retry,
+// pause/resume) so they aren't scattered across HTTP handlers. Stopping a
+// job actually reaches the running worker via a context.CancelFunc kept
+// per in-flight job.
+type Controller struct {
+	engine *OSINTEngine
+
+	mu      sync.Mutex
+	cancels map[string]context.CancelFunc
+}
+
+// NewController creates a Controller for e.
+func NewController(e *OSINTEngine) *Controller {
+	return &Controller{
+		engine:  e,
+		cancels: make(map[string]context.CancelFunc),
+	}
+}
+
+// LaunchJob submits job through the engine, the same as SubmitJob, but via
+// the Controller so callers have a single entry point for job operations.
+func (c *Controller) LaunchJob(job *Job) error {
+	return c.engine.SubmitJob(job)
+}
+
+func (c *Controller) registerCancel(jobID string, cancel context.CancelFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cancels[jobID] = cancel
+}
+
+func (c *Controller) clearCancel(jobID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.cancels, jobID)
+}
+
+// StopJob cancels the context of a running job, if any. If the job is
+// in-flight, cancellation alone is enough: the worker notices ctx is done,
+// marks the job "stopped" itself, and processResults persists that full
+// record through the normal pipeline - writing a stub here would only
+// race that write. If the job isn't in-flight (already finished, or still
+// queued and never picked up), we own recording "stopped" ourselves, by
+// updating whatever record already exists rather than clobbering it.
+func (c *Controller) StopJob(jobID string) error {
+	c.mu.Lock()
+	cancel, ok := c.cancels[jobID]
+	c.mu.Unlock()
+
+	if ok {
+		cancel()
+		return nil
+	}
+
+	raw, err := c.engine.redis.HGet(c.engine.ctx, completedJobsKey, jobID).Result()
+	var job Job
+	if err == nil {
+		if err := json.Unmarshal([]byte(raw), &job); err != nil {
+			return err
+		}
+	} else {
+		log.Printf("StopJob: %s has no existing record (still queued?), recording a stopped stub", jobID)
+		job = Job{ID: jobID}
+	}
+
+	job.Status = "stopped"
+	data, err := json.Marshal(&job)
+	if err != nil {
+		return err
+	}
+	if err := c.engine.redis.HSet(c.engine.ctx, completedJobsKey, jobID, data).Err(); err != nil {
+		return err
+	}
+	c.engine.events.Publish(jobID, "stopped", nil)
+	return nil
+}
+
+// RetryJob resubmits the job identified by jobID as a fresh job, preserving
+// its original type/target/config/priority.
+func (c *Controller) RetryJob(jobID string) error {
+	raw, err := c.engine.redis.HGet(c.engine.ctx, completedJobsKey, jobID).Result()
+	if err != nil {
+		return fmt.Errorf("cannot retry job %s: %w", jobID, err)
+	}
+
+	var original Job
+	if err := json.Unmarshal([]byte(raw), &original); err != nil {
+		return err
+	}
+
+	retry := &Job{
+		Type:       original.Type,
+		Target:     original.Target,
+		Config:     original.Config,
+		Priority:   original.Priority,
+		WebhookURL: original.WebhookURL,
+		Attempts:   original.Attempts + 1,
+	}
+	return c.engine.SubmitJob(retry)
+}
+
+// PauseType stops workers from picking up new jobs of the given type.
+// Jobs already in flight are unaffected.
+func (c *Controller) PauseType(jobType string) error {
+	return c.engine.redis.Set(c.engine.ctx, fmt.Sprintf(pausedTypeKeyFmt, jobType), "1", 0).Err()
+}
+
+// ResumeType lifts a pause set by PauseType.
+func (c *Controller) ResumeType(jobType string) error {
+	return c.engine.redis.Del(c.engine.ctx, fmt.Sprintf(pausedTypeKeyFmt, jobType)).Err()
+}
+
+func (c *Controller) isPaused(jobType string) bool {
+	n, err := c.engine.redis.Exists(c.engine.ctx, fmt.Sprintf(pausedTypeKeyFmt, jobType)).Result()
+	return err == nil && n == 1
+}
+
+// requeueDelayed sends a paused job back to the tail of the worker pool's
+// in-memory jobQueue after a short delay, rather than busy-looping on it.
+func (c *Controller) requeueDelayed(job *Job) {
+	time.AfterFunc(pauseRequeueDelay, func() {
+		select {
+		case c.engine.workerPool.jobQueue <- job:
+		case <-c.engine.ctx.Done():
+		}
+	})
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// JobStatusUpdate is a single entry in a job's ordered event stream.
+type JobStatusUpdate struct {
+	State     string                 `json:"state"`
+	Detail    map[string]interface{} `json:"detail,omitempty"`
+	Timestamp time.Time              `json:"timestamp"`
+}
+
+// terminalStates are the only states allowed to be the last event on a
+// job's stream.
+var terminalStates = map[string]bool{
+	"completed": true,
+	"failed":    true,
+	"stopped":   true,
+}
+
+func jobEventsStreamKey(jobID string) string {
+	return fmt.Sprintf("job_events:%s", jobID)
+}
+
+// JobEventBus fans updates for each job through a single per-job goroutine
+// so writes to that job's Redis Stream are strictly ordered, and so the
+// terminal event (completed/failed/stopped) is always the last entry observed by
+// a subscriber.
+type JobEventBus struct {
+	redis *redis.Client
+	ctx   context.Context
+
+	mu      sync.Mutex
+	queues  map[string]chan JobStatusUpdate
+}
+
+// NewJobEventBus creates a bus sharing the engine's Redis connection and
+// lifecycle context.
+func NewJobEventBus(ctx context.Context, rdb *redis.Client) *JobEventBus {
+	return &JobEventBus{
+		redis:  rdb,
+		ctx:    ctx,
+		queues: make(map[string]chan JobStatusUpdate),
+	}
+}
+
+// Publish enqueues a status update for jobID. Non-terminal updates are
+// written as soon as their turn comes up; a terminal update is held until
+// every update queued ahead of it has been durably written.
+func (b *JobEventBus) Publish(jobID, state string, detail map[string]interface{}) {
+	b.queueFor(jobID) <- JobStatusUpdate{
+		State:     state,
+		Detail:    detail,
+		Timestamp: time.Now(),
+	}
+}
+
+func (b *JobEventBus) queueFor(jobID string) chan<- JobStatusUpdate {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if ch, ok := b.queues[jobID]; ok {
+		return ch
+	}
+
+	ch := make(chan JobStatusUpdate, 64)
+	b.queues[jobID] = ch
+	go b.drain(jobID, ch)
+	return ch
+}
+
+// drain is the single goroutine responsible for a given job's stream. It
+// processes updates strictly in the order Publish was called, retrying
+// transient Redis errors, and tears itself down once it has durably
+// written a terminal event.
+func (b *JobEventBus) drain(jobID string, ch chan JobStatusUpdate) {
+	defer func() {
+		b.mu.Lock()
+		delete(b.queues, jobID)
+		b.mu.Unlock()
+	}()
+
+	for {
+		select {
+		case update, ok := <-ch:
+			if !ok {
+				return
+			}
+			if !b.writeWithRetry(jobID, update) {
+				// The stream is now missing an entry: writing a later
+				// (possibly terminal) event would falsely tell subscribers
+				// every update up to it was durably observed. Stop instead
+				// of papering over the gap.
+				return
+			}
+			if terminalStates[update.State] {
+				return
+			}
+		case <-b.ctx.Done():
+			return
+		}
+	}
+}
+
+// writeWithRetry XADDs update to jobID's stream, retrying transient
+// failures, and reports whether the write ultimately succeeded.
+func (b *JobEventBus) writeWithRetry(jobID string, update JobStatusUpdate) bool {
+	data, err := json.Marshal(update)
+	if err != nil {
+		log.Printf("JobEventBus: failed to marshal update for job %s: %v", jobID, err)
+		return false
+	}
+
+	backoff := 100 * time.Millisecond
+	for attempt := 1; attempt <= 5; attempt++ {
+		err := b.redis.XAdd(b.ctx, &redis.XAddArgs{
+			Stream: jobEventsStreamKey(jobID),
+			Values: map[string]interface{}{"update": data},
+		}).Err()
+		if err == nil {
+			return true
+		}
+
+		log.Printf("JobEventBus: XADD failed for job %s (attempt %d): %v", jobID, attempt, err)
+		select {
+		case <-time.After(backoff):
+			backoff *= 2
+		case <-b.ctx.Done():
+			return false
+		}
+	}
+
+	log.Printf("JobEventBus: giving up durably writing %s event for job %s", update.State, jobID)
+	return false
+}
+
+// ReadEvents long-polls job_events:<jobID> for entries after `from` (a
+// Redis Stream ID, or "0" to read from the beginning).
+func (b *JobEventBus) ReadEvents(jobID, from string, block time.Duration) ([]redis.XMessage, error) {
+	streams, err := b.redis.XRead(b.ctx, &redis.XReadArgs{
+		Streams: []string{jobEventsStreamKey(jobID), from},
+		Block:   block,
+	}).Result()
+	if err == redis.Nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if len(streams) == 0 {
+		return nil, nil
+	}
+	return streams[0].Messages, nil
+}
@@ -0,0 +1,305 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// HookEvent represents a single job state transition to be delivered to a
+// registered webhook.
+type HookEvent struct {
+	JobID      string    `json:"job_id"`
+	WebhookURL string    `json:"webhook_url"`
+	State      string    `json:"state"` // queued, running, completed, failed, stopped, ai_analyzed, security_configured
+	Job        *Job      `json:"job,omitempty"`
+	Timestamp  time.Time `json:"timestamp"`
+	Attempt    int       `json:"attempt"`
+}
+
+// HookDelivery records the outcome of a single delivery attempt, surfaced
+// via GET /jobs/:id/hooks.
+type HookDelivery struct {
+	State      string    `json:"state"`
+	URL        string    `json:"url"`
+	Attempt    int       `json:"attempt"`
+	StatusCode int       `json:"status_code"`
+	LatencyMS  int64     `json:"latency_ms"`
+	Error      string    `json:"error,omitempty"`
+	SentAt     time.Time `json:"sent_at"`
+}
+
+const (
+	hookRetryQueueKey     = "hook_retry_queue"
+	hookDeliveriesKeyFmt  = "hook_deliveries:%s"
+	hookMaxAttempts       = 6
+	hookSenderPoolSize    = 5
+	hookDeliveryHistoryMax = 50
+)
+
+// HookManager delivers job lifecycle events to caller-registered webhook
+// URLs, retrying failed deliveries with exponential backoff and persisting
+// the retry queue in Redis so restarts don't lose pending events.
+type HookManager struct {
+	redis     *redis.Client
+	ctx       context.Context
+	client    *http.Client
+	secret    []byte
+	events    chan HookEvent
+}
+
+// NewHookManager wires up a HookManager sharing the engine's Redis
+// connection and lifecycle context.
+func NewHookManager(ctx context.Context, rdb *redis.Client) *HookManager {
+	return &HookManager{
+		redis:  rdb,
+		ctx:    ctx,
+		client: &http.Client{Timeout: 10 * time.Second},
+		secret: []byte(getEnv("HOOK_SIGNING_SECRET", "")),
+		events: make(chan HookEvent, 1000),
+	}
+}
+
+// Start launches the sender pool and the retry-queue drainer.
+func (hm *HookManager) Start() {
+	for i := 0; i < hookSenderPoolSize; i++ {
+		go hm.sender(i)
+	}
+	go hm.retryLoop()
+	log.Printf("Started %d hook sender workers", hookSenderPoolSize)
+}
+
+// Emit queues a state-transition event for delivery if the job registered a
+// webhook URL. The job is snapshotted immediately: ExecuteJob keeps
+// mutating the same *Job after this returns, and delivery happens
+// asynchronously on the sender pool, so sharing the pointer would race
+// with those writes (and could serialize a "running" job as "completed").
+func (hm *HookManager) Emit(job *Job, state string) {
+	if job.WebhookURL == "" {
+		return
+	}
+
+	snapshot, err := snapshotJob(job)
+	if err != nil {
+		log.Printf("Hook manager: failed to snapshot job %s for webhook delivery: %v", job.ID, err)
+		return
+	}
+
+	event := HookEvent{
+		JobID:      job.ID,
+		WebhookURL: job.WebhookURL,
+		State:      state,
+		Job:        snapshot,
+		Timestamp:  time.Now(),
+		Attempt:    1,
+	}
+
+	select {
+	case hm.events <- event:
+	default:
+		log.Printf("Hook event channel full, persisting %s/%s directly to retry queue", job.ID, state)
+		hm.persistForRetry(event, 0)
+	}
+}
+
+// snapshotJob deep-copies job so a queued HookEvent is immune to later
+// in-place mutation of the live job.
+func snapshotJob(job *Job) (*Job, error) {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return nil, err
+	}
+	var snapshot Job
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+func (hm *HookManager) sender(id int) {
+	for event := range hm.events {
+		hm.deliver(event)
+	}
+}
+
+func (hm *HookManager) deliver(event HookEvent) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("Hook worker: failed to marshal event for job %s: %v", event.JobID, err)
+		return
+	}
+
+	start := time.Now()
+	req, err := http.NewRequestWithContext(hm.ctx, http.MethodPost, event.WebhookURL, bytes.NewReader(payload))
+	if err != nil {
+		hm.recordDelivery(event, 0, time.Since(start), err)
+		hm.scheduleRetry(event)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Huginn-Signature", hm.sign(payload))
+
+	resp, err := hm.client.Do(req)
+	latency := time.Since(start)
+	if err != nil {
+		hm.recordDelivery(event, 0, latency, err)
+		hm.scheduleRetry(event)
+		return
+	}
+	defer resp.Body.Close()
+
+	hm.recordDelivery(event, resp.StatusCode, latency, nil)
+	if resp.StatusCode >= 300 {
+		hm.scheduleRetry(event)
+	}
+}
+
+func (hm *HookManager) sign(payload []byte) string {
+	mac := hmac.New(sha256.New, hm.secret)
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (hm *HookManager) recordDelivery(event HookEvent, statusCode int, latency time.Duration, deliverErr error) {
+	delivery := HookDelivery{
+		State:      event.State,
+		URL:        event.WebhookURL,
+		Attempt:    event.Attempt,
+		StatusCode: statusCode,
+		LatencyMS:  latency.Milliseconds(),
+		SentAt:     time.Now(),
+	}
+	if deliverErr != nil {
+		delivery.Error = deliverErr.Error()
+		log.Printf("Hook delivery failed for job %s (%s), attempt %d: %v", event.JobID, event.State, event.Attempt, deliverErr)
+	}
+
+	data, err := json.Marshal(delivery)
+	if err != nil {
+		return
+	}
+
+	key := fmt.Sprintf(hookDeliveriesKeyFmt, event.JobID)
+	pipe := hm.redis.TxPipeline()
+	pipe.RPush(hm.ctx, key, data)
+	pipe.LTrim(hm.ctx, key, -hookDeliveryHistoryMax, -1)
+	if _, err := pipe.Exec(hm.ctx); err != nil {
+		log.Printf("Error recording hook delivery history for job %s: %v", event.JobID, err)
+	}
+}
+
+// scheduleRetry is the single path a failed delivery takes back towards
+// redelivery: it persists the event, scored by its next-attempt time, to
+// the hook_retry_queue sorted set. There is no separate in-memory resend
+// timer - retryLoop's periodic drain is the only thing that ever re-enters
+// hm.events, so a given failure can never produce two deliveries.
+func (hm *HookManager) scheduleRetry(event HookEvent) {
+	if event.Attempt >= hookMaxAttempts {
+		log.Printf("Hook delivery for job %s (%s) exhausted retries, giving up", event.JobID, event.State)
+		return
+	}
+
+	event.Attempt++
+	backoff := time.Duration(1<<uint(event.Attempt)) * time.Second
+	hm.persistForRetry(event, backoff)
+}
+
+// persistForRetry ZADDs event to hook_retry_queue, scored by the unix time
+// it becomes eligible for redelivery (now, if delay is zero).
+func (hm *HookManager) persistForRetry(event HookEvent, delay time.Duration) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("Error marshaling hook retry for job %s: %v", event.JobID, err)
+		return
+	}
+
+	score := float64(time.Now().Add(delay).Unix())
+	if err := hm.redis.ZAdd(hm.ctx, hookRetryQueueKey, &redis.Z{Score: score, Member: data}).Err(); err != nil {
+		log.Printf("Error persisting hook retry for job %s: %v", event.JobID, err)
+	}
+}
+
+// retryLoop periodically re-enters due entries from hook_retry_queue into
+// the delivery pool, including on startup so a restart doesn't lose
+// pending retries.
+func (hm *HookManager) retryLoop() {
+	hm.drainDueRetries()
+
+	ticker := time.NewTicker(15 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			hm.drainDueRetries()
+		case <-hm.ctx.Done():
+			return
+		}
+	}
+}
+
+func (hm *HookManager) drainDueRetries() {
+	now := fmt.Sprintf("%d", time.Now().Unix())
+	due, err := hm.redis.ZRangeByScore(hm.ctx, hookRetryQueueKey, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: now,
+	}).Result()
+	if err != nil {
+		log.Printf("Error scanning hook retry queue: %v", err)
+		return
+	}
+
+	for _, raw := range due {
+		// Remove before resending so a success never leaves a stale copy
+		// behind, and a renewed failure re-adds it under a fresh score
+		// instead of colliding with this removal.
+		removed, err := hm.redis.ZRem(hm.ctx, hookRetryQueueKey, raw).Result()
+		if err != nil {
+			log.Printf("Error removing due hook retry entry: %v", err)
+			continue
+		}
+		if removed == 0 {
+			continue // another replica already took this entry
+		}
+
+		var event HookEvent
+		if err := json.Unmarshal([]byte(raw), &event); err != nil {
+			log.Printf("Skipping unparseable hook retry entry: %v", err)
+			continue
+		}
+
+		select {
+		case hm.events <- event:
+		default:
+			hm.persistForRetry(event, 0)
+		}
+	}
+}
+
+// DeliveryHistory returns the recorded delivery attempts for a job, oldest
+// first.
+func (hm *HookManager) DeliveryHistory(jobID string) ([]HookDelivery, error) {
+	key := fmt.Sprintf(hookDeliveriesKeyFmt, jobID)
+	raw, err := hm.redis.LRange(hm.ctx, key, 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]HookDelivery, 0, len(raw))
+	for _, item := range raw {
+		var d HookDelivery
+		if err := json.Unmarshal([]byte(item), &d); err != nil {
+			continue
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,26 @@
+package main
+
+import "testing"
+
+func TestHookManagerSign(t *testing.T) {
+	hm := &HookManager{secret: []byte("top-secret")}
+
+	payload := []byte(`{"job_id":"job_1","state":"completed"}`)
+	sig := hm.sign(payload)
+
+	if sig == "" {
+		t.Fatal("expected a non-empty signature")
+	}
+	if got := hm.sign(payload); got != sig {
+		t.Errorf("signature not deterministic: got %q and %q for the same payload", sig, got)
+	}
+
+	other := &HookManager{secret: []byte("different-secret")}
+	if other.sign(payload) == sig {
+		t.Error("expected signatures to differ when the secret differs")
+	}
+
+	if hm.sign([]byte(`{"job_id":"job_2"}`)) == sig {
+		t.Error("expected signatures to differ when the payload differs")
+	}
+}
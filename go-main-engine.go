@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -24,6 +25,10 @@ type OSINTEngine struct {
 	workerPool      *WorkerPool
 	proxyManager    *ProxyManager
 	antiDetect      *AntiDetectManager
+	hooks           *HookManager
+	sweeper         *Sweeper
+	events          *JobEventBus
+	controller      *Controller
 	ctx             context.Context
 	cancel          context.CancelFunc
 }
@@ -40,6 +45,8 @@ type Job struct {
 	Results     map[string]interface{} `json:"results,omitempty"`
 	AIAnalysis  *AIAnalysisResult     `json:"ai_analysis,omitempty"`
 	SecurityMeta *SecurityMetadata     `json:"security_meta,omitempty"`
+	WebhookURL  string                `json:"webhook_url,omitempty"`
+	Attempts    int                   `json:"attempts,omitempty"`
 }
 
 // SecurityMetadata from Rust security service
@@ -137,6 +144,11 @@ func NewOSINTEngine() *OSINTEngine {
 		healthCheck: 5 * time.Minute,
 	}
 
+	engine.hooks = NewHookManager(ctx, rdb)
+	engine.sweeper = NewSweeper(engine, getEnvDuration("SWEEPER_INTERVAL", 10*time.Minute))
+	engine.events = NewJobEventBus(ctx, rdb)
+	engine.controller = NewController(engine)
+
 	engine.workerPool = &WorkerPool{
 		workers:    getEnvInt("WORKER_POOL_SIZE", 10),
 		jobQueue:   make(chan *Job, 1000),
@@ -152,6 +164,11 @@ func NewOSINTEngine() *OSINTEngine {
 func (e *OSINTEngine) Start() error {
 	log.Println("Starting OSINT Engine with multi-language integration...")
 
+	// Load any third-party scrapers shipped as Go plugins
+	if err := loadScraperPlugins(getEnv("SCRAPER_PLUGIN_DIR", "")); err != nil {
+		return fmt.Errorf("failed to load scraper plugins: %w", err)
+	}
+
 	// Test connections to Rust and Python services
 	if err := e.testServiceConnections(); err != nil {
 		return fmt.Errorf("service connection test failed: %w", err)
@@ -160,12 +177,23 @@ func (e *OSINTEngine) Start() error {
 	// Start worker pool
 	e.workerPool.Start()
 
+	// Start webhook delivery pool
+	e.hooks.Start()
+
+	// Start the job sweeper
+	e.sweeper.Start()
+
 	// Start proxy health checker
 	go e.proxyManager.healthChecker()
 
 	// Start result processor
 	go e.processResults()
 
+	// Start periodic job scheduler
+	if err := e.startPeriodicScheduler(); err != nil {
+		return fmt.Errorf("failed to start periodic scheduler: %w", err)
+	}
+
 	log.Println("OSINT Engine started successfully")
 	return nil
 }
@@ -215,61 +243,87 @@ func (e *OSINTEngine) SubmitJob(job *Job) error {
 		return fmt.Errorf("job queue full")
 	}
 
+	e.hooks.Emit(job, "queued")
+	e.events.Publish(job.ID, "queued", nil)
+
 	return nil
 }
 
+// errJobPaused is returned by ExecuteJob when job's type is paused. It is
+// not a failure: the job has been handed back to requeueDelayed, so the
+// worker must not treat it as a finished result.
+var errJobPaused = errors.New("job type is paused; requeued for later")
+
 // ExecuteJob processes a single scraping job with full integration
 func (e *OSINTEngine) ExecuteJob(job *Job) error {
+	if e.controller.isPaused(job.Type) {
+		e.controller.requeueDelayed(job)
+		return errJobPaused
+	}
+
+	ctx, cancel := context.WithCancel(e.ctx)
+	e.controller.registerCancel(job.ID, cancel)
+	defer e.controller.clearCancel(job.ID)
+
 	log.Printf("Executing job %s of type %s", job.ID, job.Type)
+	job.Status = "running"
+	e.hooks.Emit(job, "running")
+	e.events.Publish(job.ID, "running", nil)
 
 	// Step 1: Get security configuration from Rust service
-	securityMeta, err := e.getSecurityConfiguration(job)
+	securityMeta, err := e.getSecurityConfiguration(ctx, job)
 	if err != nil {
 		log.Printf("Warning: Could not get security configuration: %v", err)
 		// Continue with default security settings
 	} else {
 		job.SecurityMeta = securityMeta
+		e.hooks.Emit(job, "security_configured")
+		e.events.Publish(job.ID, "security_configured", nil)
 	}
 
 	// Step 2: Perform the actual scraping
-	var results map[string]interface{}
-	switch job.Type {
-	case "email":
-		results, err = e.scrapeEmails(job)
-	case "subdomain":
-		results, err = e.scrapeSubdomains(job)
-	case "social_media":
-		results, err = e.scrapeSocialMedia(job)
-	case "general":
-		results, err = e.scrapeGeneral(job)
-	default:
+	scraper, ok := defaultScraperRegistry.Lookup(job.Type)
+	if !ok {
 		return fmt.Errorf("unknown job type: %s", job.Type)
 	}
 
+	e.events.Publish(job.ID, "scrape_started", map[string]interface{}{"job_type": job.Type})
+	results, err := scraper.Scrape(ctx, job, job.SecurityMeta)
 	if err != nil {
-		job.Status = "failed"
+		if !errors.Is(err, context.Canceled) {
+			job.Status = "failed"
+			e.events.Publish(job.ID, "failed", map[string]interface{}{"error": err.Error()})
+		}
 		return err
 	}
 
 	job.Results = results
+	e.events.Publish(job.ID, "scrape_progress", map[string]interface{}{"results": len(results)})
 
 	// Step 3: Send results to Python AI service for analysis
-	aiAnalysis, err := e.analyzeWithAI(job)
+	aiAnalysis, err := e.analyzeWithAI(ctx, job)
 	if err != nil {
 		log.Printf("Warning: AI analysis failed: %v", err)
 		// Continue without AI analysis
 	} else {
 		job.AIAnalysis = aiAnalysis
+		e.hooks.Emit(job, "ai_analyzed")
+		e.events.Publish(job.ID, "ai_analyzed", nil)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
 	}
 
 	job.Status = "completed"
+	e.events.Publish(job.ID, "completed", nil)
 	log.Printf("Job %s completed successfully", job.ID)
 
 	return nil
 }
 
 // getSecurityConfiguration requests security parameters from Rust service
-func (e *OSINTEngine) getSecurityConfiguration(job *Job) (*SecurityMetadata, error) {
+func (e *OSINTEngine) getSecurityConfiguration(ctx context.Context, job *Job) (*SecurityMetadata, error) {
 	reqData := map[string]interface{}{
 		"target":    job.Target,
 		"job_type":  job.Type,
@@ -281,11 +335,13 @@ func (e *OSINTEngine) getSecurityConfiguration(job *Job) (*SecurityMetadata, err
 		return nil, err
 	}
 
-	resp, err := http.Post(
-		e.rustSecurityURL+"/security/configure",
-		"application/json",
-		strings.NewReader(string(jsonData)),
-	)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.rustSecurityURL+"/security/configure", strings.NewReader(string(jsonData)))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -300,7 +356,7 @@ func (e *OSINTEngine) getSecurityConfiguration(job *Job) (*SecurityMetadata, err
 }
 
 // analyzeWithAI sends scraped data to Python AI service for analysis
-func (e *OSINTEngine) analyzeWithAI(job *Job) (*AIAnalysisResult, error) {
+func (e *OSINTEngine) analyzeWithAI(ctx context.Context, job *Job) (*AIAnalysisResult, error) {
 	reqData := map[string]interface{}{
 		"job_id":     job.ID,
 		"job_type":   job.Type,
@@ -314,11 +370,13 @@ func (e *OSINTEngine) analyzeWithAI(job *Job) (*AIAnalysisResult, error) {
 		return nil, err
 	}
 
-	resp, err := http.Post(
-		e.pythonAIURL+"/analyze",
-		"application/json",
-		strings.NewReader(string(jsonData)),
-	)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.pythonAIURL+"/analyze", strings.NewReader(string(jsonData)))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -332,44 +390,6 @@ func (e *OSINTEngine) analyzeWithAI(job *Job) (*AIAnalysisResult, error) {
 	return &aiResult, nil
 }
 
-// Scraping methods with enhanced anti-detection
-func (e *OSINTEngine) scrapeEmails(job *Job) (map[string]interface{}, error) {
-	// Implementation with Rust security integration
-	return map[string]interface{}{
-		"emails_found": []string{},
-		"domains":      []string{},
-		"confidence":   0.0,
-	}, nil
-}
-
-func (e *OSINTEngine) scrapeSubdomains(job *Job) (map[string]interface{}, error) {
-	// Implementation with enhanced subdomain discovery
-	return map[string]interface{}{
-		"subdomains":    []string{},
-		"active_hosts":  []string{},
-		"technologies":  []string{},
-	}, nil
-}
-
-func (e *OSINTEngine) scrapeSocialMedia(job *Job) (map[string]interface{}, error) {
-	// Implementation with social media profiling
-	return map[string]interface{}{
-		"profiles":     []map[string]interface{}{},
-		"connections":  []string{},
-		"activity":     map[string]interface{}{},
-	}, nil
-}
-
-func (e *OSINTEngine) scrapeGeneral(job *Job) (map[string]interface{}, error) {
-	// General purpose scraping with intelligent content extraction
-	return map[string]interface{}{
-		"content":      "",
-		"links":        []string{},
-		"metadata":     map[string]interface{}{},
-		"technologies": []string{},
-	}, nil
-}
-
 // Worker pool implementation
 func (wp *WorkerPool) Start() {
 	for i := 0; i < wp.workers; i++ {
@@ -387,10 +407,29 @@ func (wp *WorkerPool) worker(id int) {
 		select {
 		case job := <-wp.jobQueue:
 			log.Printf("Worker %d processing job %s", id, job.ID)
+
+			wp.engine.markJobRunning(job)
+			stopHeartbeat := wp.engine.startHeartbeat(job.ID)
 			err := wp.engine.ExecuteJob(job)
+			stopHeartbeat()
+			wp.engine.clearJobRunning(job.ID)
+
+			if errors.Is(err, errJobPaused) {
+				// Already handed back to the queue by requeueDelayed; this
+				// isn't a result, so it must not reach resultChan/hooks.
+				continue
+			}
+
 			if err != nil {
-				job.Status = "failed"
-				log.Printf("Worker %d: Job %s failed: %v", id, job.ID, err)
+				if errors.Is(err, context.Canceled) {
+					job.Status = "stopped"
+					wp.engine.events.Publish(job.ID, "stopped", nil)
+					log.Printf("Worker %d: Job %s stopped", id, job.ID)
+				} else if job.Status != "failed" {
+					job.Status = "failed"
+					wp.engine.events.Publish(job.ID, "failed", map[string]interface{}{"error": err.Error()})
+					log.Printf("Worker %d: Job %s failed: %v", id, job.ID, err)
+				}
 			}
 			wp.resultChan <- job
 
@@ -426,6 +465,7 @@ func (e *OSINTEngine) processResults() {
 
 		// Notify completion
 		e.redis.Publish(e.ctx, "job_completed", job.ID)
+		e.hooks.Emit(job, job.Status)
 		log.Printf("Job %s results processed and stored", job.ID)
 	}
 }
@@ -474,6 +514,100 @@ func (e *OSINTEngine) setupRoutes() *gin.Engine {
 		c.JSON(200, job)
 	})
 
+	// Register a periodic (cron-scheduled) job
+	r.POST("/jobs/periodic", func(c *gin.Context) {
+		var spec PeriodicJobSpec
+		if err := c.ShouldBindJSON(&spec); err != nil {
+			c.JSON(400, gin.H{"error": err.Error()})
+			return
+		}
+
+		if err := e.SchedulePeriodicJob(spec); err != nil {
+			c.JSON(500, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(201, gin.H{"policy_id": specHash(spec)})
+	})
+
+	// Remove a periodic job
+	r.DELETE("/jobs/periodic/:id", func(c *gin.Context) {
+		if err := e.DeletePeriodicJob(c.Param("id")); err != nil {
+			c.JSON(500, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(200, gin.H{"status": "deleted"})
+	})
+
+	// Stop a running or queued job
+	r.POST("/jobs/:id/stop", func(c *gin.Context) {
+		if err := e.controller.StopJob(c.Param("id")); err != nil {
+			c.JSON(500, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(200, gin.H{"status": "stopped"})
+	})
+
+	// Retry a completed or failed job as a new job
+	r.POST("/jobs/:id/retry", func(c *gin.Context) {
+		if err := e.controller.RetryJob(c.Param("id")); err != nil {
+			c.JSON(500, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(200, gin.H{"status": "retried"})
+	})
+
+	// Pause a job type so workers stop picking up new jobs of that type
+	r.POST("/jobs/types/:type/pause", func(c *gin.Context) {
+		if err := e.controller.PauseType(c.Param("type")); err != nil {
+			c.JSON(500, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(200, gin.H{"status": "paused"})
+	})
+
+	// Resume a previously paused job type
+	r.POST("/jobs/types/:type/resume", func(c *gin.Context) {
+		if err := e.controller.ResumeType(c.Param("type")); err != nil {
+			c.JSON(500, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(200, gin.H{"status": "resumed"})
+	})
+
+	// Long-poll for ordered status updates from a given stream position
+	r.GET("/jobs/:id/events", func(c *gin.Context) {
+		from := c.DefaultQuery("from", "0")
+
+		messages, err := e.events.ReadEvents(c.Param("id"), from, 30*time.Second)
+		if err != nil {
+			c.JSON(500, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(200, gin.H{"job_id": c.Param("id"), "events": messages})
+	})
+
+	// Prometheus metrics (includes sweeper counters)
+	r.GET("/metrics", metricsHandler())
+
+	// List registered scraper backends
+	r.GET("/scrapers", func(c *gin.Context) {
+		c.JSON(200, gin.H{"scrapers": defaultScraperRegistry.List()})
+	})
+
+	// Inspect webhook delivery history for a job
+	r.GET("/jobs/:id/hooks", func(c *gin.Context) {
+		deliveries, err := e.hooks.DeliveryHistory(c.Param("id"))
+		if err != nil {
+			c.JSON(500, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(200, gin.H{"job_id": c.Param("id"), "deliveries": deliveries})
+	})
+
 	return r
 }
 
@@ -494,6 +628,15 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	return defaultValue
+}
+
 func generateJobID() string {
 	return fmt.Sprintf("job_%d_%s", time.Now().Unix(), 
 		strings.ReplaceAll(uuid.New().String(), "-", "")[:8])
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/rand"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/robfig/cron/v3"
+)
+
+// PeriodicJobSpec describes a recurring scrape registered by a caller.
+type PeriodicJobSpec struct {
+	ID       string                 `json:"id,omitempty"`
+	Type     string                 `json:"type"`
+	Target   string                 `json:"target"`
+	Config   map[string]interface{} `json:"config"`
+	Priority int                    `json:"priority"`
+	CronExpr string                 `json:"cron_expr"`
+	Jitter   time.Duration          `json:"jitter"`
+	Timezone string                 `json:"timezone"`
+}
+
+const (
+	periodicPoliciesKey = "periodic_policies"
+	periodicScheduleKey = "periodic_schedule"
+)
+
+// popDueScheduleScript atomically reads and removes entries from
+// periodic_schedule whose score is <= now, so that only one replica
+// ever dequeues a given fire.
+var popDueScheduleScript = redis.NewScript(`
+local due = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1])
+if #due > 0 then
+	redis.call('ZREM', KEYS[1], unpack(due))
+end
+return due
+`)
+
+// specHash returns a stable identifier for a spec so that re-registering
+// an identical policy is idempotent.
+func specHash(spec PeriodicJobSpec) string {
+	h := sha1.New()
+	fmt.Fprintf(h, "%s|%s|%s|%d|%v|%s|%s", spec.Type, spec.Target, spec.CronExpr, spec.Priority, spec.Config, spec.Jitter, spec.Timezone)
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+func (spec PeriodicJobSpec) location() *time.Location {
+	if spec.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(spec.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+func (spec PeriodicJobSpec) nextFireTime(from time.Time) (time.Time, error) {
+	schedule, err := cron.ParseStandard(spec.CronExpr)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid cron expression %q: %w", spec.CronExpr, err)
+	}
+	next := schedule.Next(from.In(spec.location()))
+	if spec.Jitter > 0 {
+		next = next.Add(time.Duration(rand.Int63n(int64(spec.Jitter))))
+	}
+	return next, nil
+}
+
+// SchedulePeriodicJob registers (or updates) a recurring scrape. Specs are
+// stored in a Redis hash keyed by a stable hash of their contents, and the
+// next fire time is tracked in the periodic_schedule sorted set.
+func (e *OSINTEngine) SchedulePeriodicJob(spec PeriodicJobSpec) error {
+	if spec.CronExpr == "" {
+		return fmt.Errorf("cron expression is required")
+	}
+
+	spec.ID = specHash(spec)
+
+	next, err := spec.nextFireTime(time.Now())
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return err
+	}
+
+	if err := e.redis.HSet(e.ctx, periodicPoliciesKey, spec.ID, data).Err(); err != nil {
+		return err
+	}
+
+	if err := e.redis.ZAdd(e.ctx, periodicScheduleKey, &redis.Z{
+		Score:  float64(next.Unix()),
+		Member: spec.ID,
+	}).Err(); err != nil {
+		return err
+	}
+
+	log.Printf("Registered periodic job %s (%s), next fire at %s", spec.ID, spec.CronExpr, next)
+	return nil
+}
+
+// DeletePeriodicJob removes a registered policy so it no longer fires.
+func (e *OSINTEngine) DeletePeriodicJob(id string) error {
+	if err := e.redis.HDel(e.ctx, periodicPoliciesKey, id).Err(); err != nil {
+		return err
+	}
+	return e.redis.ZRem(e.ctx, periodicScheduleKey, id).Err()
+}
+
+// startPeriodicScheduler reloads policies from Redis and begins polling for
+// due entries.
+func (e *OSINTEngine) startPeriodicScheduler() error {
+	if err := e.reloadPeriodicPolicies(); err != nil {
+		return fmt.Errorf("reload periodic policies: %w", err)
+	}
+	go e.periodicSchedulerLoop()
+	return nil
+}
+
+// reloadPeriodicPolicies ensures every stored spec has a corresponding
+// entry in periodic_schedule, so schedules survive an engine restart.
+func (e *OSINTEngine) reloadPeriodicPolicies() error {
+	policies, err := e.redis.HGetAll(e.ctx, periodicPoliciesKey).Result()
+	if err != nil && err != redis.Nil {
+		return err
+	}
+
+	now := time.Now()
+	for id, raw := range policies {
+		var spec PeriodicJobSpec
+		if err := json.Unmarshal([]byte(raw), &spec); err != nil {
+			log.Printf("Skipping unparseable periodic policy %s: %v", id, err)
+			continue
+		}
+
+		score, err := e.redis.ZScore(e.ctx, periodicScheduleKey, id).Result()
+		if err == nil && score > float64(now.Unix()) {
+			continue
+		}
+
+		next, err := spec.nextFireTime(now)
+		if err != nil {
+			log.Printf("Skipping periodic policy %s with invalid cron: %v", id, err)
+			continue
+		}
+
+		if err := e.redis.ZAdd(e.ctx, periodicScheduleKey, &redis.Z{
+			Score:  float64(next.Unix()),
+			Member: id,
+		}).Err(); err != nil {
+			return err
+		}
+	}
+
+	log.Printf("Reloaded %d periodic job policies", len(policies))
+	return nil
+}
+
+func (e *OSINTEngine) periodicSchedulerLoop() {
+	ticker := time.NewTicker(15 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			e.fireDuePeriodicJobs()
+		case <-e.ctx.Done():
+			return
+		}
+	}
+}
+
+func (e *OSINTEngine) fireDuePeriodicJobs() {
+	now := time.Now()
+
+	due, err := popDueScheduleScript.Run(e.ctx, e.redis, []string{periodicScheduleKey}, now.Unix()).StringSlice()
+	if err != nil {
+		log.Printf("Error popping due periodic jobs: %v", err)
+		return
+	}
+
+	for _, id := range due {
+		raw, err := e.redis.HGet(e.ctx, periodicPoliciesKey, id).Result()
+		if err == redis.Nil {
+			continue // policy was deleted since it was scheduled
+		} else if err != nil {
+			log.Printf("Error loading periodic policy %s: %v", id, err)
+			continue
+		}
+
+		var spec PeriodicJobSpec
+		if err := json.Unmarshal([]byte(raw), &spec); err != nil {
+			log.Printf("Skipping unparseable periodic policy %s: %v", id, err)
+			continue
+		}
+
+		job := &Job{
+			Type:     spec.Type,
+			Target:   spec.Target,
+			Config:   spec.Config,
+			Priority: spec.Priority,
+		}
+		if err := e.SubmitJob(job); err != nil {
+			log.Printf("Error materializing periodic job %s: %v", id, err)
+		}
+
+		next, err := spec.nextFireTime(now)
+		if err != nil {
+			log.Printf("Error computing next fire time for %s: %v", id, err)
+			continue
+		}
+		if err := e.redis.ZAdd(e.ctx, periodicScheduleKey, &redis.Z{
+			Score:  float64(next.Unix()),
+			Member: id,
+		}).Err(); err != nil {
+			log.Printf("Error rescheduling periodic job %s: %v", id, err)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSpecHash(t *testing.T) {
+	base := PeriodicJobSpec{
+		Type:     "subdomain",
+		Target:   "example.com",
+		CronExpr: "*/30 * * * *",
+		Priority: 1,
+		Config:   map[string]interface{}{"depth": 2},
+	}
+
+	tests := []struct {
+		name   string
+		modify func(PeriodicJobSpec) PeriodicJobSpec
+	}{
+		{"jitter differs", func(s PeriodicJobSpec) PeriodicJobSpec {
+			s.Jitter = 5 * time.Minute
+			return s
+		}},
+		{"timezone differs", func(s PeriodicJobSpec) PeriodicJobSpec {
+			s.Timezone = "America/New_York"
+			return s
+		}},
+		{"target differs", func(s PeriodicJobSpec) PeriodicJobSpec {
+			s.Target = "other.com"
+			return s
+		}},
+	}
+
+	baseHash := specHash(base)
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := specHash(tc.modify(base)); got == baseHash {
+				t.Errorf("expected a different hash when %s, got the same value %q", tc.name, got)
+			}
+		})
+	}
+
+	if specHash(base) != specHash(base) {
+		t.Error("specHash must be deterministic for identical specs")
+	}
+}
+
+func TestPeriodicJobSpecNextFireTime(t *testing.T) {
+	spec := PeriodicJobSpec{CronExpr: "0 * * * *", Timezone: "UTC"}
+
+	from := time.Date(2026, 1, 1, 10, 15, 0, 0, time.UTC)
+	next, err := spec.nextFireTime(from)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := time.Date(2026, 1, 1, 11, 0, 0, 0, time.UTC)
+	if !next.Equal(want) {
+		t.Errorf("next fire time = %v, want %v", next, want)
+	}
+}
+
+func TestPeriodicJobSpecNextFireTimeInvalidCron(t *testing.T) {
+	spec := PeriodicJobSpec{CronExpr: "not a cron expression"}
+	if _, err := spec.nextFireTime(time.Now()); err == nil {
+		t.Error("expected an error for an invalid cron expression")
+	}
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"plugin"
+	"strings"
+	"sync"
+)
+
+// Scraper is implemented by anything that can carry out a scrape for a
+// given job type. Implementations should respect ctx cancellation and use
+// sec (when present) to drive anti-detection behaviour such as proxy or
+// user-agent selection.
+type Scraper interface {
+	Scrape(ctx context.Context, job *Job, sec *SecurityMetadata) (map[string]interface{}, error)
+}
+
+// ScraperInfo describes a registered scraper for discovery via GET /scrapers.
+type ScraperInfo struct {
+	JobType        string   `json:"job_type"`
+	RateLimitHint  string   `json:"rate_limit_hint,omitempty"`
+	RequiredConfig []string `json:"required_config,omitempty"`
+}
+
+// ScraperMetadata is optionally implemented by a Scraper to advertise hints
+// surfaced via GET /scrapers.
+type ScraperMetadata interface {
+	Metadata() ScraperInfo
+}
+
+// ScraperRegistry maps job types to the Scraper that handles them. Built-in
+// scrapers register themselves via init(); third-party packages (or Go
+// plugins loaded from SCRAPER_PLUGIN_DIR) can add new job types without touching
+// engine code.
+type ScraperRegistry struct {
+	mu       sync.RWMutex
+	scrapers map[string]Scraper
+}
+
+var defaultScraperRegistry = &ScraperRegistry{
+	scrapers: make(map[string]Scraper),
+}
+
+// Register adds a scraper for jobType. It returns an error if the type is
+// already registered, so two packages can't silently clobber each other.
+func (r *ScraperRegistry) Register(jobType string, s Scraper) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.scrapers[jobType]; exists {
+		return fmt.Errorf("scraper for job type %q already registered", jobType)
+	}
+	r.scrapers[jobType] = s
+	return nil
+}
+
+// Lookup returns the scraper registered for jobType, if any.
+func (r *ScraperRegistry) Lookup(jobType string) (Scraper, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.scrapers[jobType]
+	return s, ok
+}
+
+// List returns discovery metadata for every registered job type.
+func (r *ScraperRegistry) List() []ScraperInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	infos := make([]ScraperInfo, 0, len(r.scrapers))
+	for jobType, s := range r.scrapers {
+		info := ScraperInfo{JobType: jobType}
+		if md, ok := s.(ScraperMetadata); ok {
+			info = md.Metadata()
+			info.JobType = jobType
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// RegisterScraper registers jobType against the default, process-wide
+// registry. Built-in scrapers call this from init().
+func RegisterScraper(jobType string, s Scraper) {
+	if err := defaultScraperRegistry.Register(jobType, s); err != nil {
+		panic(err)
+	}
+}
+
+// loadScraperPlugins opens every .so file in dir. Each plugin is expected
+// to register its job type(s) against RegisterScraper from its own init(),
+// the same way the built-in scrapers in this file do.
+func loadScraperPlugins(dir string) error {
+	if dir == "" {
+		return nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("reading scraper plugin dir %s: %w", dir, err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".so") {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		if _, err := plugin.Open(path); err != nil {
+			return fmt.Errorf("loading scraper plugin %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+func init() {
+	RegisterScraper("email", emailScraper{})
+	RegisterScraper("subdomain", subdomainScraper{})
+	RegisterScraper("social_media", socialMediaScraper{})
+	RegisterScraper("general", generalScraper{})
+}
+
+// emailScraper implements enhanced email harvesting with Rust security
+// integration.
+type emailScraper struct{}
+
+func (emailScraper) Scrape(ctx context.Context, job *Job, sec *SecurityMetadata) (map[string]interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"emails_found": []string{},
+		"domains":      []string{},
+		"confidence":   0.0,
+	}, nil
+}
+
+func (emailScraper) Metadata() ScraperInfo {
+	return ScraperInfo{RateLimitHint: "1 req/s per domain"}
+}
+
+// subdomainScraper implements enhanced subdomain discovery.
+type subdomainScraper struct{}
+
+func (subdomainScraper) Scrape(ctx context.Context, job *Job, sec *SecurityMetadata) (map[string]interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"subdomains":   []string{},
+		"active_hosts": []string{},
+		"technologies": []string{},
+	}, nil
+}
+
+// socialMediaScraper implements social media profiling.
+type socialMediaScraper struct{}
+
+func (socialMediaScraper) Scrape(ctx context.Context, job *Job, sec *SecurityMetadata) (map[string]interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"profiles":    []map[string]interface{}{},
+		"connections": []string{},
+		"activity":    map[string]interface{}{},
+	}, nil
+}
+
+// generalScraper implements general purpose, intelligent content extraction.
+type generalScraper struct{}
+
+func (generalScraper) Scrape(ctx context.Context, job *Job, sec *SecurityMetadata) (map[string]interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"content":      "",
+		"links":        []string{},
+		"metadata":     map[string]interface{}{},
+		"technologies": []string{},
+	}, nil
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"testing"
+)
+
+type stubScraper struct{}
+
+func (stubScraper) Scrape(ctx context.Context, job *Job, sec *SecurityMetadata) (map[string]interface{}, error) {
+	return nil, nil
+}
+
+func TestScraperRegistryRegisterAndLookup(t *testing.T) {
+	r := &ScraperRegistry{scrapers: make(map[string]Scraper)}
+
+	if _, ok := r.Lookup("paste_sites"); ok {
+		t.Fatal("expected Lookup to fail for an unregistered job type")
+	}
+
+	if err := r.Register("paste_sites", stubScraper{}); err != nil {
+		t.Fatalf("unexpected error registering: %v", err)
+	}
+
+	s, ok := r.Lookup("paste_sites")
+	if !ok {
+		t.Fatal("expected Lookup to succeed after Register")
+	}
+	if _, ok := s.(stubScraper); !ok {
+		t.Errorf("Lookup returned a different scraper than the one registered: %T", s)
+	}
+
+	if err := r.Register("paste_sites", stubScraper{}); err == nil {
+		t.Error("expected Register to error when a job type is already registered")
+	}
+}
+
+func TestScraperRegistryListIncludesMetadata(t *testing.T) {
+	r := &ScraperRegistry{scrapers: make(map[string]Scraper)}
+	if err := r.Register("email", emailScraper{}); err != nil {
+		t.Fatalf("unexpected error registering: %v", err)
+	}
+
+	infos := r.List()
+	if len(infos) != 1 {
+		t.Fatalf("expected 1 registered scraper, got %d", len(infos))
+	}
+	if infos[0].JobType != "email" {
+		t.Errorf("JobType = %q, want %q", infos[0].JobType, "email")
+	}
+	if infos[0].RateLimitHint == "" {
+		t.Error("expected emailScraper's metadata rate limit hint to be surfaced")
+	}
+}
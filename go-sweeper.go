@@ -0,0 +1,295 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	heartbeatKeyFmt        = "job_heartbeat:%s"
+	heartbeatInterval      = 15 * time.Second
+	runningJobsKey         = "running_jobs"
+	completedJobsKey       = "completed_jobs"
+	archiveKeyFmt          = "completed_jobs_archive:%s"
+	deadLetterKey          = "dead_letter"
+	defaultJobTTL          = 7 * 24 * time.Hour
+	maxJobDuration         = 30 * time.Minute
+	sweeperMaxJobAttempts  = 3
+)
+
+var (
+	sweeperJobsArchived = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "huginn_sweeper_jobs_archived_total",
+		Help: "Completed jobs moved to the compressed daily archive.",
+	})
+	sweeperJobsSwept = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "huginn_sweeper_jobs_swept_total",
+		Help: "Orphaned jobs requeued by the sweeper.",
+	})
+	sweeperJobsDeadLettered = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "huginn_sweeper_jobs_dead_lettered_total",
+		Help: "Orphaned jobs moved to the dead_letter list after exhausting retries.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(sweeperJobsArchived, sweeperJobsSwept, sweeperJobsDeadLettered)
+}
+
+// startHeartbeat begins heartbeating jobID into Redis every
+// heartbeatInterval and returns a function that stops it.
+func (e *OSINTEngine) startHeartbeat(jobID string) func() {
+	done := make(chan struct{})
+
+	go func() {
+		ticker := time.NewTicker(heartbeatInterval)
+		defer ticker.Stop()
+
+		key := fmt.Sprintf(heartbeatKeyFmt, jobID)
+		e.redis.Set(e.ctx, key, time.Now().Unix(), maxJobDuration)
+
+		for {
+			select {
+			case <-ticker.C:
+				e.redis.Set(e.ctx, key, time.Now().Unix(), maxJobDuration)
+			case <-done:
+				e.redis.Del(e.ctx, key)
+				return
+			case <-e.ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
+
+// markJobRunning records job's full body in the running_jobs hash,
+// independent of its heartbeat key, so the sweeper has a durable registry
+// of in-flight jobs - including everything needed to requeue or
+// dead-letter them - to reap even after their heartbeat has expired.
+func (e *OSINTEngine) markJobRunning(job *Job) {
+	data, err := json.Marshal(job)
+	if err != nil {
+		log.Printf("Error marshaling job %s for running_jobs: %v", job.ID, err)
+		return
+	}
+	e.redis.HSet(e.ctx, runningJobsKey, job.ID, data)
+}
+
+// clearJobRunning removes jobID from the running_jobs hash once the worker
+// is done with it: it finished, or it was handed back because its type is
+// paused.
+func (e *OSINTEngine) clearJobRunning(jobID string) {
+	e.redis.HDel(e.ctx, runningJobsKey, jobID)
+}
+
+// Sweeper enforces retention and liveness invariants across the
+// Redis-backed job state: it archives old completed jobs, requeues or
+// dead-letters orphaned running jobs, and purges exhausted hook retries.
+type Sweeper struct {
+	engine   *OSINTEngine
+	interval time.Duration
+	jobTTL   time.Duration
+}
+
+// NewSweeper creates a Sweeper that runs every interval against e.
+func NewSweeper(e *OSINTEngine, interval time.Duration) *Sweeper {
+	if interval <= 0 {
+		interval = 10 * time.Minute
+	}
+	return &Sweeper{engine: e, interval: interval, jobTTL: defaultJobTTL}
+}
+
+// Start runs the sweep loop until the engine's context is cancelled.
+func (s *Sweeper) Start() {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.sweep()
+			case <-s.engine.ctx.Done():
+				return
+			}
+		}
+	}()
+	log.Printf("Sweeper started, interval=%s, jobTTL=%s", s.interval, s.jobTTL)
+}
+
+func (s *Sweeper) sweep() {
+	s.archiveStaleCompletedJobs()
+	s.reapOrphanedJobs()
+	s.purgeExhaustedHookRetries()
+}
+
+// archiveStaleCompletedJobs moves completed_jobs entries older than jobTTL
+// into a per-day archive hash so completed_jobs stays small.
+func (s *Sweeper) archiveStaleCompletedJobs() {
+	ctx := s.engine.ctx
+	entries, err := s.engine.redis.HGetAll(ctx, completedJobsKey).Result()
+	if err != nil {
+		log.Printf("Sweeper: error scanning %s: %v", completedJobsKey, err)
+		return
+	}
+
+	cutoff := time.Now().Add(-s.jobTTL)
+	for id, raw := range entries {
+		var job Job
+		if err := json.Unmarshal([]byte(raw), &job); err != nil {
+			continue
+		}
+		if job.CreatedAt.After(cutoff) {
+			continue
+		}
+
+		compressed, err := gzipCompress([]byte(raw))
+		if err != nil {
+			log.Printf("Sweeper: error compressing job %s for archival: %v", id, err)
+			continue
+		}
+
+		archiveKey := fmt.Sprintf(archiveKeyFmt, job.CreatedAt.Format("20060102"))
+		pipe := s.engine.redis.TxPipeline()
+		pipe.HSet(ctx, archiveKey, id, compressed)
+		pipe.HDel(ctx, completedJobsKey, id)
+		if _, err := pipe.Exec(ctx); err != nil {
+			log.Printf("Sweeper: error archiving job %s: %v", id, err)
+			continue
+		}
+		sweeperJobsArchived.Inc()
+	}
+}
+
+// gzipCompress compresses data for storage in a completed_jobs_archive
+// hash; callers gunzip before unmarshaling.
+func gzipCompress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	if _, err := gw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := gw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// gzipDecompress reverses gzipCompress.
+func gzipDecompress(data []byte) ([]byte, error) {
+	gr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer gr.Close()
+	return io.ReadAll(gr)
+}
+
+// reapOrphanedJobs walks the running_jobs registry (populated independently
+// of the heartbeat keys, on job dequeue) and reaps any member whose
+// job_heartbeat:<id> key is absent - i.e. has expired because the worker
+// handling it died or got stuck. A SCAN over job_heartbeat:* would only
+// ever see keys that still exist, so it can never find an expired one;
+// running_jobs is what lets us notice the absence.
+func (s *Sweeper) reapOrphanedJobs() {
+	ctx := s.engine.ctx
+
+	entries, err := s.engine.redis.HGetAll(ctx, runningJobsKey).Result()
+	if err != nil {
+		log.Printf("Sweeper: error reading %s: %v", runningJobsKey, err)
+		return
+	}
+
+	for jobID, raw := range entries {
+		exists, err := s.engine.redis.Exists(ctx, fmt.Sprintf(heartbeatKeyFmt, jobID)).Result()
+		if err != nil || exists == 1 {
+			continue // still alive, or we failed closed
+		}
+
+		var job Job
+		if err := json.Unmarshal([]byte(raw), &job); err != nil {
+			log.Printf("Sweeper: error unmarshaling running_jobs entry %s: %v", jobID, err)
+		} else {
+			s.requeueOrDeadLetter(&job)
+		}
+		s.engine.redis.HDel(ctx, runningJobsKey, jobID)
+	}
+}
+
+// requeueOrDeadLetter requeues job - reconstructed from its persisted
+// running_jobs body, so it carries its original type/target/config/etc
+// rather than just an ID - or moves it to the dead letter list once it has
+// been orphaned too many times.
+func (s *Sweeper) requeueOrDeadLetter(job *Job) {
+	ctx := s.engine.ctx
+
+	if _, err := s.engine.redis.HGet(ctx, completedJobsKey, job.ID).Result(); err == nil {
+		return // job completed normally between the scan and this check
+	}
+
+	attemptsKey := fmt.Sprintf("job_attempts:%s", job.ID)
+	attempts, err := s.engine.redis.Incr(ctx, attemptsKey).Result()
+	if err != nil {
+		log.Printf("Sweeper: error incrementing attempts for job %s: %v", job.ID, err)
+		return
+	}
+
+	job.Status = "queued"
+	job.Attempts = int(attempts)
+
+	if attempts > sweeperMaxJobAttempts {
+		data, _ := json.Marshal(job)
+		s.engine.redis.LPush(ctx, deadLetterKey, data)
+		s.engine.redis.Del(ctx, attemptsKey)
+		sweeperJobsDeadLettered.Inc()
+		log.Printf("Sweeper: job %s dead-lettered after %d attempts", job.ID, attempts)
+		return
+	}
+
+	select {
+	case s.engine.workerPool.jobQueue <- job:
+		sweeperJobsSwept.Inc()
+		log.Printf("Sweeper: requeued orphaned job %s (attempt %d)", job.ID, attempts)
+	default:
+		log.Printf("Sweeper: job queue full, could not requeue orphaned job %s", job.ID)
+	}
+}
+
+// purgeExhaustedHookRetries drops hook_retry_queue entries that have
+// already exceeded hookMaxAttempts so the sorted set doesn't grow
+// unbounded.
+func (s *Sweeper) purgeExhaustedHookRetries() {
+	ctx := s.engine.ctx
+	members, err := s.engine.redis.ZRange(ctx, hookRetryQueueKey, 0, -1).Result()
+	if err != nil {
+		log.Printf("Sweeper: error scanning %s: %v", hookRetryQueueKey, err)
+		return
+	}
+
+	for _, item := range members {
+		var event HookEvent
+		if err := json.Unmarshal([]byte(item), &event); err != nil {
+			continue
+		}
+		if event.Attempt > hookMaxAttempts {
+			s.engine.redis.ZRem(ctx, hookRetryQueueKey, item)
+		}
+	}
+}
+
+// metricsHandler exposes sweeper (and other) Prometheus metrics.
+func metricsHandler() gin.HandlerFunc {
+	h := promhttp.Handler()
+	return gin.WrapH(h)
+}